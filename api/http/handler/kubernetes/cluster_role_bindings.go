@@ -0,0 +1,231 @@
+package kubernetes
+
+import (
+	"net/http"
+
+	portainer "github.com/portainer/portainer/api"
+	models "github.com/portainer/portainer/api/http/models/kubernetes"
+	httperror "github.com/portainer/portainer/pkg/libhttp/error"
+	"github.com/portainer/portainer/pkg/libhttp/request"
+	"github.com/portainer/portainer/pkg/libhttp/response"
+	"github.com/rs/zerolog/log"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// validateClusterRoleBindingReferences checks that the referenced ClusterRole and any ServiceAccount
+// subjects exist, mirroring validateRoleBindingReferences for the cluster-scoped case. Unlike a namespaced
+// role binding, a ClusterRoleBinding has no namespace of its own to default a ServiceAccount subject's
+// namespace to, so ServiceAccount subjects must carry an explicit namespace.
+func validateClusterRoleBindingReferences(cli portainer.KubeClient, roleRef string, subjects []models.K8sRoleBindingSubject) (*K8sRoleBindingInvalidReferencesError, error) {
+	invalid := &K8sRoleBindingInvalidReferencesError{}
+
+	if _, err := cli.GetClusterRole(roleRef); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		invalid.MissingRoleRef = roleRef
+	}
+
+	for _, subject := range subjects {
+		if subject.Kind != "ServiceAccount" {
+			continue
+		}
+
+		if subject.Namespace == "" {
+			invalid.MissingServiceAccounts = append(invalid.MissingServiceAccounts, subject.Name)
+			continue
+		}
+
+		if _, err := cli.GetServiceAccount(subject.Namespace, subject.Name); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+
+			invalid.MissingServiceAccounts = append(invalid.MissingServiceAccounts, subject.Namespace+"/"+subject.Name)
+		}
+	}
+
+	if invalid.MissingRoleRef == "" && len(invalid.MissingServiceAccounts) == 0 {
+		return nil, nil
+	}
+
+	return invalid, nil
+}
+
+// @id GetKubernetesClusterRoleBindings
+// @summary Get a list of kubernetes cluster role bindings
+// @description Get a list of kubernetes cluster role bindings that the user has access to.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @produce json
+// @param id path int true "Environment identifier"
+// @success 200 {array} kubernetes.K8sRoleBinding "Success"
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier."
+// @failure 500 "Server error occurred while attempting to retrieve the list of cluster role bindings."
+// @router /kubernetes/{id}/clusterrolebindings [get]
+func (handler *Handler) getAllKubernetesClusterRoleBindings(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	cli, httpErr := handler.prepareKubeClient(r)
+	if httpErr != nil {
+		log.Error().Err(httpErr).Str("context", "GetAllKubernetesClusterRoleBindings").Msg("Unable to prepare kube client")
+		return httperror.InternalServerError("unable to prepare kube client. Error: ", httpErr)
+	}
+
+	clusterRoleBindings, err := cli.GetClusterRoleBindings()
+	if err != nil {
+		log.Error().Err(err).Str("context", "GetAllKubernetesClusterRoleBindings").Msg("Unable to fetch cluster role bindings")
+		return httperror.InternalServerError("unable to fetch cluster role bindings. Error: ", err)
+	}
+
+	return response.JSON(w, clusterRoleBindings)
+}
+
+// @id CreateKubernetesClusterRoleBinding
+// @summary Create a kubernetes cluster role binding
+// @description Create a cluster role binding. The referenced ClusterRole and any ServiceAccount subjects
+// @description are validated before the binding is created.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @accept json
+// @produce json
+// @param id path int true "Environment identifier"
+// @param payload body models.K8sClusterRoleBindingCreateRequest true "Cluster role binding details"
+// @success 200 {object} kubernetes.K8sRoleBinding "Success"
+// @failure 400 "Invalid request payload, such as missing required fields or references to a ClusterRole/service accounts that do not exist."
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier."
+// @failure 500 "Server error occurred while attempting to create the cluster role binding."
+// @router /kubernetes/{id}/cluster_role_bindings [post]
+func (handler *Handler) createClusterRoleBinding(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	var payload models.K8sClusterRoleBindingCreateRequest
+	if err := request.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return httperror.BadRequest("Invalid request payload", err)
+	}
+
+	cli, handlerErr := handler.getProxyKubeClient(r)
+	if handlerErr != nil {
+		return handlerErr
+	}
+
+	invalidRefs, err := validateClusterRoleBindingReferences(cli, payload.RoleRef, payload.Subjects)
+	if err != nil {
+		log.Error().Err(err).Str("context", "CreateKubernetesClusterRoleBinding").Msg("Unable to validate cluster role binding references")
+		return httperror.InternalServerError("Unable to validate cluster role binding references", err)
+	}
+
+	if invalidRefs != nil {
+		return httperror.BadRequest("Invalid cluster role binding references", invalidRefs)
+	}
+
+	clusterRoleBinding, err := cli.CreateClusterRoleBinding(payload)
+	if err != nil {
+		log.Error().Err(err).Str("context", "CreateKubernetesClusterRoleBinding").Msg("Unable to create cluster role binding")
+		return httperror.InternalServerError("Unable to create cluster role binding", err)
+	}
+
+	return response.JSON(w, clusterRoleBinding)
+}
+
+// @id UpdateKubernetesClusterRoleBinding
+// @summary Update a kubernetes cluster role binding
+// @description Update the roleRef and subjects of an existing cluster role binding. The referenced
+// @description ClusterRole and any ServiceAccount subjects are validated before the update is applied.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @accept json
+// @produce json
+// @param id path int true "Environment identifier"
+// @param name path string true "Cluster role binding name"
+// @param payload body models.K8sClusterRoleBindingUpdateRequest true "Cluster role binding details"
+// @success 200 {object} kubernetes.K8sRoleBinding "Success"
+// @failure 400 "Invalid request payload, such as missing required fields or references to a ClusterRole/service accounts that do not exist."
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier or the specified cluster role binding."
+// @failure 500 "Server error occurred while attempting to update the cluster role binding."
+// @router /kubernetes/{id}/cluster_role_bindings/{name} [put]
+func (handler *Handler) updateClusterRoleBinding(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	name, err := request.RetrieveRouteVariableValue(r, "name")
+	if err != nil {
+		return httperror.BadRequest("Invalid cluster role binding name route variable", err)
+	}
+
+	var payload models.K8sClusterRoleBindingUpdateRequest
+	if err := request.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return httperror.BadRequest("Invalid request payload", err)
+	}
+
+	cli, handlerErr := handler.getProxyKubeClient(r)
+	if handlerErr != nil {
+		return handlerErr
+	}
+
+	invalidRefs, err := validateClusterRoleBindingReferences(cli, payload.RoleRef, payload.Subjects)
+	if err != nil {
+		log.Error().Err(err).Str("context", "UpdateKubernetesClusterRoleBinding").Msg("Unable to validate cluster role binding references")
+		return httperror.InternalServerError("Unable to validate cluster role binding references", err)
+	}
+
+	if invalidRefs != nil {
+		return httperror.BadRequest("Invalid cluster role binding references", invalidRefs)
+	}
+
+	clusterRoleBinding, err := cli.UpdateClusterRoleBinding(name, payload)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return httperror.NotFound("Unable to find the specified cluster role binding", err)
+		}
+
+		log.Error().Err(err).Str("context", "UpdateKubernetesClusterRoleBinding").Msg("Unable to update cluster role binding")
+		return httperror.InternalServerError("Unable to update cluster role binding", err)
+	}
+
+	return response.JSON(w, clusterRoleBinding)
+}
+
+// @id DeleteClusterRoleBindings
+// @summary Delete cluster role bindings
+// @description Delete the provided list of cluster role bindings. Entries carrying a uid are deleted with
+// @description that uid as a precondition, guarding against deleting a binding recreated under the same
+// @description name since it was listed.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @accept json
+// @param id path int true "Environment identifier"
+// @param payload body models.K8sClusterRoleBindingDeleteRequests true "The list of cluster role bindings to delete"
+// @success 204 "Success"
+// @failure 400 "Invalid request payload, such as missing required fields or fields not meeting validation criteria."
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier or a specific cluster role binding."
+// @failure 500 "Server error occurred while attempting to delete cluster role bindings."
+// @router /kubernetes/{id}/cluster_role_bindings/delete [POST]
+func (handler *Handler) deleteClusterRoleBindings(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	var payload models.K8sClusterRoleBindingDeleteRequests
+	if err := request.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return httperror.BadRequest("Invalid request payload", err)
+	}
+
+	cli, handlerErr := handler.getProxyKubeClient(r)
+	if handlerErr != nil {
+		return handlerErr
+	}
+
+	if err := cli.DeleteClusterRoleBindings(payload); err != nil {
+		if apierrors.IsNotFound(err) {
+			return httperror.NotFound("Unable to find a specified cluster role binding", err)
+		}
+
+		return httperror.InternalServerError("Failed to delete cluster role bindings", err)
+	}
+
+	return response.Empty(w)
+}
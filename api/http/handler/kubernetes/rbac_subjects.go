@@ -0,0 +1,286 @@
+package kubernetes
+
+import (
+	"net/http"
+
+	portainer "github.com/portainer/portainer/api"
+	models "github.com/portainer/portainer/api/http/models/kubernetes"
+	httperror "github.com/portainer/portainer/pkg/libhttp/error"
+	"github.com/portainer/portainer/pkg/libhttp/request"
+	"github.com/portainer/portainer/pkg/libhttp/response"
+	"github.com/rs/zerolog/log"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// @id GetKubernetesRbacSubjects
+// @summary List the users, groups and service accounts referenced by role bindings
+// @description Walk every role binding and cluster role binding in the cluster and return a de-duplicated
+// @description list of the subjects they reference, together with the roles each subject holds and the
+// @description namespaces those roles apply in, so operators can answer "what can user X do?" without
+// @description manually walking bindings.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @produce json
+// @param id path int true "Environment identifier"
+// @param subjectKind query string false "Filter by subject kind: User, Group or ServiceAccount"
+// @param name query string false "Filter by subject name"
+// @success 200 {array} kubernetes.K8sRbacSubject "Success"
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier."
+// @failure 500 "Server error occurred while attempting to aggregate role binding subjects."
+// @router /kubernetes/{id}/rbac/subjects [get]
+func (handler *Handler) getKubernetesRbacSubjects(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	cli, httpErr := handler.prepareKubeClient(r)
+	if httpErr != nil {
+		log.Error().Err(httpErr).Str("context", "GetKubernetesRbacSubjects").Msg("Unable to prepare kube client")
+		return httperror.InternalServerError("unable to prepare kube client. Error: ", httpErr)
+	}
+
+	rolebindings, err := cli.GetRoleBindings("")
+	if err != nil {
+		log.Error().Err(err).Str("context", "GetKubernetesRbacSubjects").Msg("Unable to fetch rolebindings")
+		return httperror.InternalServerError("unable to fetch rolebindings. Error: ", err)
+	}
+
+	clusterRoleBindings, err := cli.GetClusterRoleBindings()
+	if err != nil {
+		log.Error().Err(err).Str("context", "GetKubernetesRbacSubjects").Msg("Unable to fetch cluster role bindings")
+		return httperror.InternalServerError("unable to fetch cluster role bindings. Error: ", err)
+	}
+
+	subjects := aggregateRbacSubjects(rolebindings, clusterRoleBindings)
+
+	subjectKind, _ := request.RetrieveQueryParameter(r, "subjectKind", true)
+	name, _ := request.RetrieveQueryParameter(r, "name", true)
+	subjects = filterRbacSubjects(subjects, subjectKind, name, "")
+
+	return response.JSON(w, subjects)
+}
+
+// aggregateRbacSubjects walks every role binding and cluster role binding and builds a de-duplicated list
+// of the subjects they reference, keyed by kind/namespace/name.
+func aggregateRbacSubjects(rolebindings, clusterRoleBindings []models.K8sRoleBinding) []models.K8sRbacSubject {
+	index := map[string]*models.K8sRbacSubject{}
+
+	addBinding := func(binding models.K8sRoleBinding, roleNamespace string) {
+		bindingRef := binding.Namespace + "/" + binding.Name
+		if binding.Namespace == "" {
+			bindingRef = binding.Name
+		}
+
+		for _, subject := range binding.Subjects {
+			key := subject.Kind + "|" + subject.Namespace + "|" + subject.Name
+
+			entry, ok := index[key]
+			if !ok {
+				entry = &models.K8sRbacSubject{
+					Kind:      subject.Kind,
+					Name:      subject.Name,
+					Namespace: subject.Namespace,
+				}
+				index[key] = entry
+			}
+
+			if !containsString(entry.BindingRefs, bindingRef) {
+				entry.BindingRefs = append(entry.BindingRefs, bindingRef)
+			}
+
+			role := models.K8sRbacSubjectRole{
+				Kind:      binding.RoleRef.Kind,
+				Name:      binding.RoleRef.Name,
+				Namespace: roleNamespace,
+			}
+			if !containsRole(entry.Roles, role) {
+				entry.Roles = append(entry.Roles, role)
+			}
+		}
+	}
+
+	for _, binding := range rolebindings {
+		addBinding(binding, binding.Namespace)
+	}
+
+	for _, binding := range clusterRoleBindings {
+		addBinding(binding, "")
+	}
+
+	subjects := make([]models.K8sRbacSubject, 0, len(index))
+	for _, entry := range index {
+		subjects = append(subjects, *entry)
+	}
+
+	return subjects
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsRole(roles []models.K8sRbacSubjectRole, role models.K8sRbacSubjectRole) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterRbacSubjects narrows subjects down by kind, name and, for ServiceAccount subjects, namespace
+// (needed because a ServiceAccount name is only unique within its namespace, not cluster-wide).
+func filterRbacSubjects(subjects []models.K8sRbacSubject, subjectKind, name, namespace string) []models.K8sRbacSubject {
+	if subjectKind == "" && name == "" && namespace == "" {
+		return subjects
+	}
+
+	filtered := make([]models.K8sRbacSubject, 0, len(subjects))
+	for _, subject := range subjects {
+		if subjectKind != "" && subject.Kind != subjectKind {
+			continue
+		}
+
+		if name != "" && subject.Name != name {
+			continue
+		}
+
+		if namespace != "" && subject.Kind == "ServiceAccount" && subject.Namespace != namespace {
+			continue
+		}
+
+		filtered = append(filtered, subject)
+	}
+
+	return filtered
+}
+
+// @id GetKubernetesRbacSubjectEffectivePermissions
+// @summary Get the effective resource/verb permission matrix for a subject
+// @description Expand every Role/ClusterRole held by the given subject, as discovered from role bindings
+// @description and cluster role bindings, into a flat resource/verb matrix. A ServiceAccount name is only
+// @description unique within its namespace, so if more than one namespace has a ServiceAccount with the
+// @description requested name, the namespace query parameter is required to disambiguate.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @produce json
+// @param id path int true "Environment identifier"
+// @param kind path string true "Subject kind: User, Group or ServiceAccount"
+// @param name path string true "Subject name"
+// @param namespace query string false "Disambiguate a ServiceAccount subject that exists in more than one namespace"
+// @success 200 {array} kubernetes.K8sRbacEffectivePermission "Success"
+// @failure 400 "Invalid request, such as an unknown subject kind or an ambiguous subject matching more than one namespace."
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier or the specified subject."
+// @failure 500 "Server error occurred while attempting to compute effective permissions."
+// @router /kubernetes/{id}/rbac/subjects/{kind}/{name}/effective-permissions [get]
+func (handler *Handler) getKubernetesRbacSubjectEffectivePermissions(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	kind, err := request.RetrieveRouteVariableValue(r, "kind")
+	if err != nil {
+		return httperror.BadRequest("Invalid subject kind route variable", err)
+	}
+
+	if kind != "User" && kind != "Group" && kind != "ServiceAccount" {
+		return httperror.BadRequest("Invalid subject kind: must be User, Group or ServiceAccount", nil)
+	}
+
+	name, err := request.RetrieveRouteVariableValue(r, "name")
+	if err != nil {
+		return httperror.BadRequest("Invalid subject name route variable", err)
+	}
+
+	namespace, _ := request.RetrieveQueryParameter(r, "namespace", true)
+
+	cli, httpErr := handler.prepareKubeClient(r)
+	if httpErr != nil {
+		log.Error().Err(httpErr).Str("context", "GetKubernetesRbacSubjectEffectivePermissions").Msg("Unable to prepare kube client")
+		return httperror.InternalServerError("unable to prepare kube client. Error: ", httpErr)
+	}
+
+	rolebindings, err := cli.GetRoleBindings("")
+	if err != nil {
+		log.Error().Err(err).Str("context", "GetKubernetesRbacSubjectEffectivePermissions").Msg("Unable to fetch rolebindings")
+		return httperror.InternalServerError("unable to fetch rolebindings. Error: ", err)
+	}
+
+	clusterRoleBindings, err := cli.GetClusterRoleBindings()
+	if err != nil {
+		log.Error().Err(err).Str("context", "GetKubernetesRbacSubjectEffectivePermissions").Msg("Unable to fetch cluster role bindings")
+		return httperror.InternalServerError("unable to fetch cluster role bindings. Error: ", err)
+	}
+
+	subjects := filterRbacSubjects(aggregateRbacSubjects(rolebindings, clusterRoleBindings), kind, name, namespace)
+	if len(subjects) == 0 {
+		return httperror.NotFound("Unable to find the specified subject", nil)
+	}
+
+	if len(subjects) > 1 {
+		return httperror.BadRequest("Multiple subjects match the given kind and name; disambiguate with the namespace query parameter", nil)
+	}
+
+	permissions, err := expandEffectivePermissions(cli, subjects[0])
+	if err != nil {
+		log.Error().Err(err).Str("context", "GetKubernetesRbacSubjectEffectivePermissions").Msg("Unable to expand effective permissions")
+		return httperror.InternalServerError("unable to expand effective permissions. Error: ", err)
+	}
+
+	return response.JSON(w, permissions)
+}
+
+// expandEffectivePermissions resolves every Role/ClusterRole a subject was found to hold into a flat
+// resource/verb matrix.
+func expandEffectivePermissions(cli portainer.KubeClient, subject models.K8sRbacSubject) ([]models.K8sRbacEffectivePermission, error) {
+	var permissions []models.K8sRbacEffectivePermission
+
+	for _, role := range subject.Roles {
+		var rules []rbacv1.PolicyRule
+
+		if role.Kind == "ClusterRole" {
+			clusterRole, err := cli.GetClusterRole(role.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			rules = clusterRole.Rules
+		} else {
+			namespacedRole, err := cli.GetRole(role.Namespace, role.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			rules = namespacedRole.Rules
+		}
+
+		for _, rule := range rules {
+			for _, resource := range rule.Resources {
+				for _, apiGroup := range apiGroupsOrEmpty(rule.APIGroups) {
+					permissions = append(permissions, models.K8sRbacEffectivePermission{
+						APIGroup:  apiGroup,
+						Resource:  resource,
+						Verbs:     rule.Verbs,
+						Namespace: role.Namespace,
+					})
+				}
+			}
+		}
+	}
+
+	return permissions, nil
+}
+
+func apiGroupsOrEmpty(apiGroups []string) []string {
+	if len(apiGroups) == 0 {
+		return []string{""}
+	}
+
+	return apiGroups
+}
@@ -0,0 +1,115 @@
+package kubernetes
+
+import (
+	"testing"
+
+	models "github.com/portainer/portainer/api/http/models/kubernetes"
+)
+
+func Test_aggregateRbacSubjects(t *testing.T) {
+	rolebindings := []models.K8sRoleBinding{
+		{
+			Name:      "view-pods",
+			Namespace: "team-a",
+			RoleRef:   models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"},
+			Subjects: []models.K8sRoleBindingSubject{
+				{Kind: "ServiceAccount", Name: "default", Namespace: "team-a"},
+			},
+		},
+		{
+			Name:      "view-pods",
+			Namespace: "team-b",
+			RoleRef:   models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"},
+			Subjects: []models.K8sRoleBindingSubject{
+				{Kind: "ServiceAccount", Name: "default", Namespace: "team-b"},
+			},
+		},
+	}
+
+	clusterRoleBindings := []models.K8sRoleBinding{
+		{
+			Name:    "cluster-admins",
+			RoleRef: models.K8sRoleBindingRoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects: []models.K8sRoleBindingSubject{
+				{Kind: "User", Name: "alice"},
+			},
+		},
+	}
+
+	subjects := aggregateRbacSubjects(rolebindings, clusterRoleBindings)
+
+	if len(subjects) != 3 {
+		t.Fatalf("expected 3 distinct subjects (two ServiceAccounts named default in different namespaces, plus one User), got %d", len(subjects))
+	}
+
+	var serviceAccounts int
+	for _, subject := range subjects {
+		if subject.Kind == "ServiceAccount" && subject.Name == "default" {
+			serviceAccounts++
+
+			if subject.Namespace == "" {
+				t.Fatalf("expected the ServiceAccount subject to retain its namespace, got empty")
+			}
+		}
+	}
+
+	if serviceAccounts != 2 {
+		t.Fatalf("expected the two namespaced default ServiceAccounts to remain distinct subjects, got %d", serviceAccounts)
+	}
+}
+
+func Test_aggregateRbacSubjects_dedupesRolesAndBindingRefsForRepeatedBindings(t *testing.T) {
+	rolebindings := []models.K8sRoleBinding{
+		{
+			Name:      "view-pods",
+			Namespace: "team-a",
+			RoleRef:   models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"},
+			Subjects: []models.K8sRoleBindingSubject{
+				{Kind: "User", Name: "alice"},
+			},
+		},
+		{
+			Name:      "view-pods-again",
+			Namespace: "team-a",
+			RoleRef:   models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"},
+			Subjects: []models.K8sRoleBindingSubject{
+				{Kind: "User", Name: "alice"},
+			},
+		},
+	}
+
+	subjects := aggregateRbacSubjects(rolebindings, nil)
+	if len(subjects) != 1 {
+		t.Fatalf("expected a single aggregated subject, got %d", len(subjects))
+	}
+
+	alice := subjects[0]
+	if len(alice.Roles) != 1 {
+		t.Fatalf("expected the duplicate pod-viewer grant to be de-duplicated into a single role, got %d", len(alice.Roles))
+	}
+
+	if len(alice.BindingRefs) != 2 {
+		t.Fatalf("expected both distinct binding refs to be kept, got %d", len(alice.BindingRefs))
+	}
+}
+
+func Test_filterRbacSubjects_namespaceDisambiguatesSameNameServiceAccounts(t *testing.T) {
+	subjects := []models.K8sRbacSubject{
+		{Kind: "ServiceAccount", Name: "default", Namespace: "team-a"},
+		{Kind: "ServiceAccount", Name: "default", Namespace: "team-b"},
+	}
+
+	all := filterRbacSubjects(subjects, "ServiceAccount", "default", "")
+	if len(all) != 2 {
+		t.Fatalf("expected both namespaced matches without a namespace filter, got %d", len(all))
+	}
+
+	scoped := filterRbacSubjects(subjects, "ServiceAccount", "default", "team-a")
+	if len(scoped) != 1 {
+		t.Fatalf("expected exactly one match once disambiguated by namespace, got %d", len(scoped))
+	}
+
+	if scoped[0].Namespace != "team-a" {
+		t.Fatalf("expected the team-a ServiceAccount, got namespace %q", scoped[0].Namespace)
+	}
+}
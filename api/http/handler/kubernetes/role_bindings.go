@@ -3,21 +3,28 @@ package kubernetes
 import (
 	"net/http"
 
+	portainer "github.com/portainer/portainer/api"
 	models "github.com/portainer/portainer/api/http/models/kubernetes"
 	httperror "github.com/portainer/portainer/pkg/libhttp/error"
 	"github.com/portainer/portainer/pkg/libhttp/request"
 	"github.com/portainer/portainer/pkg/libhttp/response"
 	"github.com/rs/zerolog/log"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // @id GetKubernetesRoleBindings
 // @summary Get a list of kubernetes role bindings
-// @description Get a list of kubernetes role bindings that the user has access to.
+// @description Get a list of kubernetes role bindings that the user has access to. When checkAccess is set
+// @description to true, each returned role binding is annotated with the caller's actual permissions
+// @description (canUpdate/canDelete) as determined by a SelfSubjectAccessReview against its namespace, so
+// @description the UI can hide actions the caller cannot perform.
 // @description **Access policy**: Authenticated user.
 // @tags kubernetes
 // @security ApiKeyAuth || jwt
 // @produce json
 // @param id path int true "Environment identifier"
+// @param checkAccess query bool false "Annotate each role binding with the caller's update/delete permissions"
 // @success 200 {array} kubernetes.K8sRoleBinding "Success"
 // @failure 400 "Invalid request payload, such as missing required fields or fields not meeting validation criteria."
 // @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
@@ -38,19 +45,243 @@ func (handler *Handler) getAllKubernetesRoleBindings(w http.ResponseWriter, r *h
 		return httperror.InternalServerError("unable to fetch rolebindings. Error: ", err)
 	}
 
-	return response.JSON(w, rolebindings)
+	checkAccess, _ := request.RetrieveBooleanQueryParameter(r, "checkAccess", true)
+	if !checkAccess {
+		return response.JSON(w, rolebindings)
+	}
+
+	annotated, err := annotateRoleBindingsWithPermissions(cli, rolebindings)
+	if err != nil {
+		log.Error().Err(err).Str("context", "GetAllKubernetesRoleBindings").Msg("Unable to determine role binding permissions")
+		return httperror.InternalServerError("unable to determine role binding permissions. Error: ", err)
+	}
+
+	return response.JSON(w, annotated)
+}
+
+// annotateRoleBindingsWithPermissions looks up permissions once per distinct namespace found in
+// rolebindings (one SelfSubjectAccessReview per checked verb) and annotates every binding in that
+// namespace with the result, avoiding redundant SARs per binding.
+func annotateRoleBindingsWithPermissions(cli portainer.KubeClient, rolebindings []models.K8sRoleBinding) ([]models.K8sRoleBindingWithPermissions, error) {
+	permissionsByNamespace := map[string]models.K8sRoleBindingPermissions{}
+	annotated := make([]models.K8sRoleBindingWithPermissions, 0, len(rolebindings))
+
+	for _, rolebinding := range rolebindings {
+		permissions, ok := permissionsByNamespace[rolebinding.Namespace]
+		if !ok {
+			var err error
+			permissions, err = cli.GetRoleBindingPermissions(rolebinding.Namespace)
+			if err != nil {
+				return nil, err
+			}
+
+			permissionsByNamespace[rolebinding.Namespace] = permissions
+		}
+
+		annotated = append(annotated, models.K8sRoleBindingWithPermissions{
+			K8sRoleBinding: rolebinding,
+			Permissions:    permissions,
+		})
+	}
+
+	return annotated, nil
+}
+
+// K8sRoleBindingInvalidReferencesError describes the roles and service accounts referenced by a role
+// binding payload that could not be resolved in the target namespace/cluster.
+type K8sRoleBindingInvalidReferencesError struct {
+	MissingRoleRef         string   `json:"missingRoleRef,omitempty"`
+	MissingServiceAccounts []string `json:"missingServiceAccounts,omitempty"`
+}
+
+func (e *K8sRoleBindingInvalidReferencesError) Error() string {
+	return "role binding references one or more roles or service accounts that do not exist"
+}
+
+// validateRoleBindingReferences checks that the Role/ClusterRole and any ServiceAccount subjects referenced
+// by a role binding payload exist. It returns a non-nil *K8sRoleBindingInvalidReferencesError describing
+// what is missing when a lookup comes back not-found, and a non-nil error when a lookup itself failed for
+// some other reason (API server unreachable, RBAC denying the lookup, ...) so callers can tell a bad
+// request apart from a server-side failure.
+func validateRoleBindingReferences(cli portainer.KubeClient, namespace string, roleRef models.K8sRoleBindingRoleRef, subjects []models.K8sRoleBindingSubject) (*K8sRoleBindingInvalidReferencesError, error) {
+	invalid := &K8sRoleBindingInvalidReferencesError{}
+
+	if roleRef.Kind == "ClusterRole" {
+		if _, err := cli.GetClusterRole(roleRef.Name); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+
+			invalid.MissingRoleRef = roleRef.Name
+		}
+	} else {
+		if _, err := cli.GetRole(namespace, roleRef.Name); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+
+			invalid.MissingRoleRef = roleRef.Name
+		}
+	}
+
+	for _, subject := range subjects {
+		if subject.Kind != "ServiceAccount" {
+			continue
+		}
+
+		subjectNamespace := subject.Namespace
+		if subjectNamespace == "" {
+			subjectNamespace = namespace
+		}
+
+		if _, err := cli.GetServiceAccount(subjectNamespace, subject.Name); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+
+			invalid.MissingServiceAccounts = append(invalid.MissingServiceAccounts, subjectNamespace+"/"+subject.Name)
+		}
+	}
+
+	if invalid.MissingRoleRef == "" && len(invalid.MissingServiceAccounts) == 0 {
+		return nil, nil
+	}
+
+	return invalid, nil
+}
+
+// @id CreateKubernetesRoleBinding
+// @summary Create a kubernetes role binding
+// @description Create a role binding in the given namespace. The referenced Role/ClusterRole and any
+// @description ServiceAccount subjects are validated before the binding is created.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @accept json
+// @produce json
+// @param id path int true "Environment identifier"
+// @param namespace path string true "Namespace"
+// @param payload body models.K8sRoleBindingCreateRequest true "Role binding details"
+// @success 200 {object} kubernetes.K8sRoleBinding "Success"
+// @failure 400 "Invalid request payload, such as missing required fields or references to roles/service accounts that do not exist."
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier."
+// @failure 500 "Server error occurred while attempting to create the role binding."
+// @router /kubernetes/{id}/namespaces/{namespace}/rolebindings [post]
+func (handler *Handler) createRoleBinding(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	namespace, err := request.RetrieveRouteVariableValue(r, "namespace")
+	if err != nil {
+		return httperror.BadRequest("Invalid namespace identifier route variable", err)
+	}
+
+	var payload models.K8sRoleBindingCreateRequest
+	if err := request.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return httperror.BadRequest("Invalid request payload", err)
+	}
+
+	cli, handlerErr := handler.getProxyKubeClient(r)
+	if handlerErr != nil {
+		return handlerErr
+	}
+
+	invalidRefs, err := validateRoleBindingReferences(cli, namespace, payload.RoleRef, payload.Subjects)
+	if err != nil {
+		log.Error().Err(err).Str("context", "CreateKubernetesRoleBinding").Msg("Unable to validate role binding references")
+		return httperror.InternalServerError("Unable to validate role binding references", err)
+	}
+
+	if invalidRefs != nil {
+		return httperror.BadRequest("Invalid role binding references", invalidRefs)
+	}
+
+	rolebinding, err := cli.CreateRoleBinding(namespace, payload)
+	if err != nil {
+		log.Error().Err(err).Str("context", "CreateKubernetesRoleBinding").Msg("Unable to create role binding")
+		return httperror.InternalServerError("Unable to create role binding", err)
+	}
+
+	return response.JSON(w, rolebinding)
+}
+
+// @id UpdateKubernetesRoleBinding
+// @summary Update a kubernetes role binding
+// @description Update the roleRef and subjects of an existing role binding. The referenced Role/ClusterRole
+// @description and any ServiceAccount subjects are validated before the update is applied.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @accept json
+// @produce json
+// @param id path int true "Environment identifier"
+// @param namespace path string true "Namespace"
+// @param name path string true "Role binding name"
+// @param payload body models.K8sRoleBindingUpdateRequest true "Role binding details"
+// @success 200 {object} kubernetes.K8sRoleBinding "Success"
+// @failure 400 "Invalid request payload, such as missing required fields or references to roles/service accounts that do not exist."
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier or the specified role binding."
+// @failure 500 "Server error occurred while attempting to update the role binding."
+// @router /kubernetes/{id}/namespaces/{namespace}/rolebindings/{name} [put]
+func (handler *Handler) updateRoleBinding(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	namespace, err := request.RetrieveRouteVariableValue(r, "namespace")
+	if err != nil {
+		return httperror.BadRequest("Invalid namespace identifier route variable", err)
+	}
+
+	name, err := request.RetrieveRouteVariableValue(r, "name")
+	if err != nil {
+		return httperror.BadRequest("Invalid role binding name route variable", err)
+	}
+
+	var payload models.K8sRoleBindingUpdateRequest
+	if err := request.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return httperror.BadRequest("Invalid request payload", err)
+	}
+
+	cli, handlerErr := handler.getProxyKubeClient(r)
+	if handlerErr != nil {
+		return handlerErr
+	}
+
+	invalidRefs, err := validateRoleBindingReferences(cli, namespace, payload.RoleRef, payload.Subjects)
+	if err != nil {
+		log.Error().Err(err).Str("context", "UpdateKubernetesRoleBinding").Msg("Unable to validate role binding references")
+		return httperror.InternalServerError("Unable to validate role binding references", err)
+	}
+
+	if invalidRefs != nil {
+		return httperror.BadRequest("Invalid role binding references", invalidRefs)
+	}
+
+	rolebinding, err := cli.UpdateRoleBinding(namespace, name, payload)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return httperror.NotFound("Unable to find the specified role binding", err)
+		}
+
+		log.Error().Err(err).Str("context", "UpdateKubernetesRoleBinding").Msg("Unable to update role binding")
+		return httperror.InternalServerError("Unable to update role binding", err)
+	}
+
+	return response.JSON(w, rolebinding)
 }
 
 // @id DeleteRoleBindings
 // @summary Delete role bindings
-// @description Delete the provided list of role bindings.
+// @description Delete the provided list of role bindings. The response reports which bindings were
+// @description deleted and which failed rather than aborting on the first error: HTTP 207 is returned
+// @description whenever at least one of the requested bindings failed to delete, including when all did.
 // @description **Access policy**: Authenticated user.
 // @tags kubernetes
 // @security ApiKeyAuth || jwt
 // @accept json
+// @produce json
 // @param id path int true "Environment identifier"
 // @param payload body models.K8sRoleBindingDeleteRequests true "A map where the key is the namespace and the value is an array of role bindings to delete"
-// @success 204 "Success"
+// @success 200 {object} kubernetes.K8sRoleBindingDeleteResponse "Success"
+// @success 207 {object} kubernetes.K8sRoleBindingDeleteResponse "Partial success, see the failed list for details"
 // @failure 400 "Invalid request payload, such as missing required fields or fields not meeting validation criteria."
 // @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
 // @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
@@ -69,9 +300,125 @@ func (h *Handler) deleteRoleBindings(w http.ResponseWriter, r *http.Request) *ht
 		return handlerErr
 	}
 
-	if err := cli.DeleteRoleBindings(payload); err != nil {
-		return httperror.InternalServerError("Failed to delete role bindings", err)
+	result := models.K8sRoleBindingDeleteResponse{}
+	for namespace, names := range payload {
+		for _, name := range names {
+			if err := cli.DeleteRoleBindings(models.K8sRoleBindingDeleteRequests{namespace: {name}}); err != nil {
+				log.Error().Err(err).Str("context", "DeleteRoleBindings").Str("namespace", namespace).Str("name", name).Msg("Unable to delete role binding")
+				result.Failed = append(result.Failed, models.K8sRoleBindingDeleteFailure{
+					Namespace: namespace,
+					Name:      name,
+					Reason:    err.Error(),
+					Code:      http.StatusInternalServerError,
+				})
+				continue
+			}
+
+			result.Deleted = append(result.Deleted, models.K8sRoleBindingDeleteResult{Namespace: namespace, Name: name})
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		return response.JSONWithStatus(w, result, http.StatusMultiStatus)
+	}
+
+	return response.JSON(w, result)
+}
+
+// @id BatchKubernetesRoleBindingOperations
+// @summary Execute a batch of role binding create/update/delete operations
+// @description Execute a mixed list of role binding create, update and delete operations in a single
+// @description request. Create and update operations are subject to the same Role/ClusterRole and
+// @description ServiceAccount reference validation as the single-item endpoints. Update and delete
+// @description operations may carry an optional resourceVersion, used as an optimistic-concurrency
+// @description precondition so that concurrent Portainer sessions don't clobber each other's edits. Every
+// @description operation is attempted and reported individually; a failure in one operation does not
+// @description prevent the others from running.
+// @description **Access policy**: Authenticated user.
+// @tags kubernetes
+// @security ApiKeyAuth || jwt
+// @accept json
+// @produce json
+// @param id path int true "Environment identifier"
+// @param payload body models.K8sRoleBindingBatchRequest true "The operations to execute"
+// @success 200 {object} kubernetes.K8sRoleBindingBatchResponse "Success"
+// @success 207 {object} kubernetes.K8sRoleBindingBatchResponse "Partial success, see the results list for details"
+// @failure 400 "Invalid request payload, such as missing required fields or fields not meeting validation criteria."
+// @failure 401 "Unauthorized access - the user is not authenticated or does not have the necessary permissions. Ensure that you have provided a valid API key or JWT token, and that you have the required permissions."
+// @failure 403 "Permission denied - the user is authenticated but does not have the necessary permissions to access the requested resource or perform the specified operation. Check your user roles and permissions."
+// @failure 404 "Unable to find an environment with the specified identifier."
+// @failure 500 "Server error occurred while attempting to execute the batch of role binding operations."
+// @router /kubernetes/{id}/role_bindings/batch [post]
+func (h *Handler) batchRoleBindingOperations(w http.ResponseWriter, r *http.Request) *httperror.HandlerError {
+	var payload models.K8sRoleBindingBatchRequest
+	if err := request.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return httperror.BadRequest("Invalid request payload", err)
+	}
+
+	cli, handlerErr := h.getProxyKubeClient(r)
+	if handlerErr != nil {
+		return handlerErr
+	}
+
+	resp := models.K8sRoleBindingBatchResponse{}
+	failed := false
+
+	for _, op := range payload.Operations {
+		result := models.K8sRoleBindingBatchResult{Op: op.Op, Namespace: op.Namespace, Name: op.Name, Success: true, Code: http.StatusOK}
+
+		if op.Op == "create" || op.Op == "update" {
+			invalidRefs, err := validateRoleBindingReferences(cli, op.Namespace, op.RoleRef, op.Subjects)
+			if err != nil {
+				log.Error().Err(err).Str("context", "BatchKubernetesRoleBindingOperations").Str("op", op.Op).Str("namespace", op.Namespace).Str("name", op.Name).Msg("Unable to validate role binding references")
+				result.Success = false
+				result.Reason = err.Error()
+				result.Code = http.StatusInternalServerError
+				failed = true
+				resp.Results = append(resp.Results, result)
+				continue
+			}
+
+			if invalidRefs != nil {
+				result.Success = false
+				result.Reason = invalidRefs.Error()
+				result.Code = http.StatusBadRequest
+				failed = true
+				resp.Results = append(resp.Results, result)
+				continue
+			}
+		}
+
+		var err error
+		switch op.Op {
+		case "create":
+			_, err = cli.CreateRoleBinding(op.Namespace, models.K8sRoleBindingCreateRequest{
+				Name:     op.Name,
+				RoleRef:  op.RoleRef,
+				Subjects: op.Subjects,
+			})
+		case "update":
+			_, err = cli.UpdateRoleBindingWithPrecondition(op.Namespace, op.Name, op.ResourceVersion, models.K8sRoleBindingUpdateRequest{
+				RoleRef:  op.RoleRef,
+				Subjects: op.Subjects,
+			})
+		case "delete":
+			err = cli.DeleteRoleBindingWithPrecondition(op.Namespace, op.Name, op.ResourceVersion)
+		}
+
+		if err != nil {
+			log.Error().Err(err).Str("context", "BatchKubernetesRoleBindingOperations").Str("op", op.Op).Str("namespace", op.Namespace).Str("name", op.Name).Msg("Unable to execute role binding operation")
+			result.Success = false
+			result.Reason = err.Error()
+			result.Code = http.StatusInternalServerError
+			failed = true
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	if failed {
+		return response.JSONWithStatus(w, resp, http.StatusMultiStatus)
 	}
 
-	return response.Empty(w)
+	return response.JSON(w, resp)
 }
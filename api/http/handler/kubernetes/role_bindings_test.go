@@ -0,0 +1,211 @@
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+
+	models "github.com/portainer/portainer/api/http/models/kubernetes"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeKubeClient is a minimal portainer.KubeClient stub letting each test wire up only the lookups
+// validateRoleBindingReferences actually exercises; every other method panics if called.
+type fakeKubeClient struct {
+	getRole            func(namespace, name string) (*rbacv1.Role, error)
+	getClusterRole     func(name string) (*rbacv1.ClusterRole, error)
+	getServiceAccount  func(namespace, name string) (*corev1.ServiceAccount, error)
+}
+
+func (f *fakeKubeClient) GetRoleBindings(namespace string) ([]models.K8sRoleBinding, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) CreateRoleBinding(namespace string, payload models.K8sRoleBindingCreateRequest) (models.K8sRoleBinding, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) UpdateRoleBinding(namespace, name string, payload models.K8sRoleBindingUpdateRequest) (models.K8sRoleBinding, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) DeleteRoleBindings(payload models.K8sRoleBindingDeleteRequests) error {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) DeleteRoleBindingWithPrecondition(namespace, name, resourceVersion string) error {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) UpdateRoleBindingWithPrecondition(namespace, name, resourceVersion string, payload models.K8sRoleBindingUpdateRequest) (models.K8sRoleBinding, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) GetRoleBindingPermissions(namespace string) (models.K8sRoleBindingPermissions, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) GetClusterRoleBindings() ([]models.K8sRoleBinding, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) CreateClusterRoleBinding(payload models.K8sClusterRoleBindingCreateRequest) (models.K8sRoleBinding, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) UpdateClusterRoleBinding(name string, payload models.K8sClusterRoleBindingUpdateRequest) (models.K8sRoleBinding, error) {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) DeleteClusterRoleBindings(payload models.K8sClusterRoleBindingDeleteRequests) error {
+	panic("not implemented")
+}
+
+func (f *fakeKubeClient) GetRole(namespace, name string) (*rbacv1.Role, error) {
+	return f.getRole(namespace, name)
+}
+
+func (f *fakeKubeClient) GetClusterRole(name string) (*rbacv1.ClusterRole, error) {
+	return f.getClusterRole(name)
+}
+
+func (f *fakeKubeClient) GetServiceAccount(namespace, name string) (*corev1.ServiceAccount, error) {
+	return f.getServiceAccount(namespace, name)
+}
+
+func notFoundErr(resource, name string) error {
+	return apierrors.NewNotFound(schema.GroupResource{Resource: resource}, name)
+}
+
+func Test_validateRoleBindingReferences(t *testing.T) {
+	t.Run("Role found, ServiceAccount found: no invalid refs, no error", func(t *testing.T) {
+		cli := &fakeKubeClient{
+			getRole:           func(namespace, name string) (*rbacv1.Role, error) { return &rbacv1.Role{}, nil },
+			getServiceAccount: func(namespace, name string) (*corev1.ServiceAccount, error) { return &corev1.ServiceAccount{}, nil },
+		}
+
+		invalid, err := validateRoleBindingReferences(cli, "team-a", models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}, []models.K8sRoleBindingSubject{
+			{Kind: "ServiceAccount", Name: "default"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if invalid != nil {
+			t.Fatalf("expected no invalid references, got %+v", invalid)
+		}
+	})
+
+	t.Run("ServiceAccount subject namespace defaults to the binding namespace", func(t *testing.T) {
+		var lookedUpNamespace string
+		cli := &fakeKubeClient{
+			getRole: func(namespace, name string) (*rbacv1.Role, error) { return &rbacv1.Role{}, nil },
+			getServiceAccount: func(namespace, name string) (*corev1.ServiceAccount, error) {
+				lookedUpNamespace = namespace
+				return &corev1.ServiceAccount{}, nil
+			},
+		}
+
+		_, err := validateRoleBindingReferences(cli, "team-a", models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}, []models.K8sRoleBindingSubject{
+			{Kind: "ServiceAccount", Name: "default"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if lookedUpNamespace != "team-a" {
+			t.Fatalf("expected the service account lookup to default to the binding namespace %q, got %q", "team-a", lookedUpNamespace)
+		}
+	})
+
+	t.Run("ClusterRole not found: reported as a missing reference", func(t *testing.T) {
+		cli := &fakeKubeClient{
+			getClusterRole: func(name string) (*rbacv1.ClusterRole, error) { return nil, notFoundErr("clusterroles", name) },
+		}
+
+		invalid, err := validateRoleBindingReferences(cli, "team-a", models.K8sRoleBindingRoleRef{Kind: "ClusterRole", Name: "missing-cr"}, nil)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if invalid == nil || invalid.MissingRoleRef != "missing-cr" {
+			t.Fatalf("expected missing-cr to be reported as a missing role ref, got %+v", invalid)
+		}
+	})
+
+	t.Run("Role lookup server error: propagated, not reported as a missing reference", func(t *testing.T) {
+		cli := &fakeKubeClient{
+			getRole: func(namespace, name string) (*rbacv1.Role, error) { return nil, errors.New("api server unreachable") },
+		}
+
+		invalid, err := validateRoleBindingReferences(cli, "team-a", models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}, nil)
+		if err == nil {
+			t.Fatalf("expected the server error to be propagated")
+		}
+
+		if invalid != nil {
+			t.Fatalf("expected no invalid references when the lookup itself failed, got %+v", invalid)
+		}
+	})
+
+	t.Run("ServiceAccount not found: reported as a missing reference", func(t *testing.T) {
+		cli := &fakeKubeClient{
+			getRole:           func(namespace, name string) (*rbacv1.Role, error) { return &rbacv1.Role{}, nil },
+			getServiceAccount: func(namespace, name string) (*corev1.ServiceAccount, error) { return nil, notFoundErr("serviceaccounts", name) },
+		}
+
+		invalid, err := validateRoleBindingReferences(cli, "team-a", models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}, []models.K8sRoleBindingSubject{
+			{Kind: "ServiceAccount", Name: "missing-sa"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if invalid == nil || len(invalid.MissingServiceAccounts) != 1 || invalid.MissingServiceAccounts[0] != "team-a/missing-sa" {
+			t.Fatalf("expected team-a/missing-sa to be reported as a missing service account, got %+v", invalid)
+		}
+	})
+
+	t.Run("ServiceAccount lookup server error: propagated, not reported as a missing reference", func(t *testing.T) {
+		cli := &fakeKubeClient{
+			getRole:           func(namespace, name string) (*rbacv1.Role, error) { return &rbacv1.Role{}, nil },
+			getServiceAccount: func(namespace, name string) (*corev1.ServiceAccount, error) { return nil, errors.New("rbac denied the lookup") },
+		}
+
+		invalid, err := validateRoleBindingReferences(cli, "team-a", models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}, []models.K8sRoleBindingSubject{
+			{Kind: "ServiceAccount", Name: "default"},
+		})
+		if err == nil {
+			t.Fatalf("expected the server error to be propagated")
+		}
+
+		if invalid != nil {
+			t.Fatalf("expected no invalid references when the lookup itself failed, got %+v", invalid)
+		}
+	})
+
+	t.Run("non-ServiceAccount subjects are not looked up", func(t *testing.T) {
+		cli := &fakeKubeClient{
+			getRole: func(namespace, name string) (*rbacv1.Role, error) { return &rbacv1.Role{}, nil },
+			getServiceAccount: func(namespace, name string) (*corev1.ServiceAccount, error) {
+				t.Fatalf("GetServiceAccount should not be called for User/Group subjects")
+				return nil, nil
+			},
+		}
+
+		invalid, err := validateRoleBindingReferences(cli, "team-a", models.K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}, []models.K8sRoleBindingSubject{
+			{Kind: "User", Name: "alice"},
+			{Kind: "Group", Name: "devs"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if invalid != nil {
+			t.Fatalf("expected no invalid references, got %+v", invalid)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import "fmt"
+
+// K8sClusterRoleBindingCreateRequest is the payload used to create a cluster role binding. Unlike a
+// namespaced role binding, the roleRef always points at a ClusterRole.
+type K8sClusterRoleBindingCreateRequest struct {
+	Name     string                  `json:"name" validate:"required"`
+	RoleRef  string                  `json:"roleRef" validate:"required"`
+	Subjects []K8sRoleBindingSubject `json:"subjects" validate:"required"`
+}
+
+func (payload K8sClusterRoleBindingCreateRequest) Validate() error {
+	if payload.Name == "" {
+		return fmt.Errorf("invalid cluster role binding name")
+	}
+
+	if payload.RoleRef == "" {
+		return fmt.Errorf("invalid roleRef: a ClusterRole name is required")
+	}
+
+	if len(payload.Subjects) == 0 {
+		return fmt.Errorf("at least one subject is required")
+	}
+
+	return nil
+}
+
+// K8sClusterRoleBindingUpdateRequest is the payload used to update an existing cluster role binding's
+// roleRef and subjects.
+type K8sClusterRoleBindingUpdateRequest struct {
+	RoleRef  string                  `json:"roleRef" validate:"required"`
+	Subjects []K8sRoleBindingSubject `json:"subjects" validate:"required"`
+}
+
+func (payload K8sClusterRoleBindingUpdateRequest) Validate() error {
+	if payload.RoleRef == "" {
+		return fmt.Errorf("invalid roleRef: a ClusterRole name is required")
+	}
+
+	if len(payload.Subjects) == 0 {
+		return fmt.Errorf("at least one subject is required")
+	}
+
+	return nil
+}
+
+// K8sClusterRoleBindingDeleteRequest identifies a cluster role binding to delete. UID, when supplied, is
+// used as a deletion precondition so a binding recreated under the same name since it was listed is not
+// accidentally removed.
+type K8sClusterRoleBindingDeleteRequest struct {
+	Name string `json:"name" validate:"required"`
+	UID  string `json:"uid,omitempty"`
+}
+
+// K8sClusterRoleBindingDeleteRequests is the payload used to delete a batch of cluster role bindings.
+type K8sClusterRoleBindingDeleteRequests []K8sClusterRoleBindingDeleteRequest
+
+func (payload K8sClusterRoleBindingDeleteRequests) Validate() error {
+	if len(payload) == 0 {
+		return fmt.Errorf("at least one cluster role binding is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package kubernetes
+
+// K8sRbacSubjectRole is a Role or ClusterRole granted to a subject through a binding, together with the
+// namespace the grant applies in (empty for a ClusterRole granted cluster-wide via a ClusterRoleBinding).
+type K8sRbacSubjectRole struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// K8sRbacSubject is a de-duplicated view of a User, Group or ServiceAccount referenced by one or more
+// role bindings / cluster role bindings, together with everything it was granted and where.
+type K8sRbacSubject struct {
+	Kind        string               `json:"kind"`
+	Name        string               `json:"name"`
+	Namespace   string               `json:"namespace,omitempty"`
+	Roles       []K8sRbacSubjectRole `json:"roles"`
+	BindingRefs []string             `json:"bindingRefs"`
+}
+
+// K8sRbacEffectivePermission is one row of a subject's effective resource/verb matrix, expanded from the
+// rules of every Role/ClusterRole it was found to hold.
+type K8sRbacEffectivePermission struct {
+	APIGroup  string   `json:"apiGroup,omitempty"`
+	Resource  string   `json:"resource"`
+	Verbs     []string `json:"verbs"`
+	Namespace string   `json:"namespace,omitempty"`
+}
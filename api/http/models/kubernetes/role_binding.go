@@ -0,0 +1,61 @@
+package kubernetes
+
+import "fmt"
+
+// K8sRoleBindingRoleRef references the Role or ClusterRole granted by a binding.
+type K8sRoleBindingRoleRef struct {
+	// Kind is either "Role" or "ClusterRole".
+	Kind string `json:"kind" validate:"required"`
+	Name string `json:"name" validate:"required"`
+}
+
+// K8sRoleBindingSubject represents a subject (User, Group or ServiceAccount) a role binding applies to.
+type K8sRoleBindingSubject struct {
+	// Kind is one of "User", "Group" or "ServiceAccount".
+	Kind string `json:"kind" validate:"required"`
+	Name string `json:"name" validate:"required"`
+	// Namespace is only relevant for ServiceAccount subjects. It defaults to the role binding's namespace
+	// when left empty.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// K8sRoleBindingCreateRequest is the payload used to create a role binding.
+type K8sRoleBindingCreateRequest struct {
+	Name     string                  `json:"name" validate:"required"`
+	RoleRef  K8sRoleBindingRoleRef   `json:"roleRef" validate:"required"`
+	Subjects []K8sRoleBindingSubject `json:"subjects" validate:"required"`
+}
+
+func (payload K8sRoleBindingCreateRequest) Validate() error {
+	if payload.Name == "" {
+		return fmt.Errorf("invalid role binding name")
+	}
+
+	if payload.RoleRef.Name == "" || (payload.RoleRef.Kind != "Role" && payload.RoleRef.Kind != "ClusterRole") {
+		return fmt.Errorf("invalid roleRef: kind must be Role or ClusterRole")
+	}
+
+	if len(payload.Subjects) == 0 {
+		return fmt.Errorf("at least one subject is required")
+	}
+
+	return nil
+}
+
+// K8sRoleBindingUpdateRequest is the payload used to update an existing role binding's roleRef and subjects.
+type K8sRoleBindingUpdateRequest struct {
+	RoleRef  K8sRoleBindingRoleRef   `json:"roleRef" validate:"required"`
+	Subjects []K8sRoleBindingSubject `json:"subjects" validate:"required"`
+}
+
+func (payload K8sRoleBindingUpdateRequest) Validate() error {
+	if payload.RoleRef.Name == "" || (payload.RoleRef.Kind != "Role" && payload.RoleRef.Kind != "ClusterRole") {
+		return fmt.Errorf("invalid roleRef: kind must be Role or ClusterRole")
+	}
+
+	if len(payload.Subjects) == 0 {
+		return fmt.Errorf("at least one subject is required")
+	}
+
+	return nil
+}
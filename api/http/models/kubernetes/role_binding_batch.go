@@ -0,0 +1,92 @@
+package kubernetes
+
+import "fmt"
+
+// K8sRoleBindingDeleteResult identifies a role binding that was successfully deleted.
+type K8sRoleBindingDeleteResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// K8sRoleBindingDeleteFailure describes why a single role binding in a bulk delete request could not be
+// deleted.
+type K8sRoleBindingDeleteFailure struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	Code      int    `json:"code"`
+}
+
+// K8sRoleBindingDeleteResponse reports which role bindings were deleted and which failed, so a bulk
+// delete no longer loses information about partial success.
+type K8sRoleBindingDeleteResponse struct {
+	Deleted []K8sRoleBindingDeleteResult  `json:"deleted"`
+	Failed  []K8sRoleBindingDeleteFailure `json:"failed,omitempty"`
+}
+
+// K8sRoleBindingBatchOperation is a single create, update or delete operation against a role binding.
+// ResourceVersion, when supplied on an update or delete, is used as an optimistic-concurrency precondition
+// so concurrent Portainer sessions don't clobber each other's edits.
+type K8sRoleBindingBatchOperation struct {
+	Op              string                  `json:"op" validate:"required"`
+	Namespace       string                  `json:"namespace" validate:"required"`
+	Name            string                  `json:"name" validate:"required"`
+	ResourceVersion string                  `json:"resourceVersion,omitempty"`
+	RoleRef         K8sRoleBindingRoleRef   `json:"roleRef,omitempty"`
+	Subjects        []K8sRoleBindingSubject `json:"subjects,omitempty"`
+}
+
+// K8sRoleBindingBatchRequest is the payload for the role binding batch endpoint, executing a mixed list
+// of create/update/delete operations in a single request.
+type K8sRoleBindingBatchRequest struct {
+	Operations []K8sRoleBindingBatchOperation `json:"operations" validate:"required"`
+}
+
+func (payload K8sRoleBindingBatchRequest) Validate() error {
+	if len(payload.Operations) == 0 {
+		return fmt.Errorf("at least one operation is required")
+	}
+
+	for _, op := range payload.Operations {
+		if op.Namespace == "" {
+			return fmt.Errorf("each operation requires a namespace")
+		}
+
+		switch op.Op {
+		case "create", "update", "delete":
+		default:
+			return fmt.Errorf("invalid op %q: must be one of create, update, delete", op.Op)
+		}
+
+		if op.Name == "" {
+			return fmt.Errorf("name is required for %q operations", op.Op)
+		}
+
+		if op.Op == "create" || op.Op == "update" {
+			if op.RoleRef.Name == "" || (op.RoleRef.Kind != "Role" && op.RoleRef.Kind != "ClusterRole") {
+				return fmt.Errorf("invalid roleRef for %q operation: kind must be Role or ClusterRole", op.Op)
+			}
+
+			if len(op.Subjects) == 0 {
+				return fmt.Errorf("at least one subject is required for %q operations", op.Op)
+			}
+		}
+	}
+
+	return nil
+}
+
+// K8sRoleBindingBatchResult is the outcome of a single operation within a batch request.
+type K8sRoleBindingBatchResult struct {
+	Op        string `json:"op"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Success   bool   `json:"success"`
+	Reason    string `json:"reason,omitempty"`
+	Code      int    `json:"code"`
+}
+
+// K8sRoleBindingBatchResponse reports the outcome of every operation submitted in a batch request.
+type K8sRoleBindingBatchResponse struct {
+	Results []K8sRoleBindingBatchResult `json:"results"`
+}
@@ -0,0 +1,104 @@
+package kubernetes
+
+import "testing"
+
+func Test_K8sRoleBindingBatchRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload K8sRoleBindingBatchRequest
+		wantErr bool
+	}{
+		{
+			name:    "no operations",
+			payload: K8sRoleBindingBatchRequest{},
+			wantErr: true,
+		},
+		{
+			name: "operation missing namespace",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{Op: "delete", Name: "view-pods"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown op",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{Op: "patch", Namespace: "team-a", Name: "view-pods"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "update missing name",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{Op: "update", Namespace: "team-a"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "create missing name",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{Op: "create", Namespace: "team-a", RoleRef: K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}, Subjects: []K8sRoleBindingSubject{{Kind: "User", Name: "alice"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "create missing roleRef",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{Op: "create", Namespace: "team-a", Name: "view-pods", Subjects: []K8sRoleBindingSubject{{Kind: "User", Name: "alice"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "create missing subjects",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{Op: "create", Namespace: "team-a", Name: "view-pods", RoleRef: K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid create",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{
+						Op:        "create",
+						Namespace: "team-a",
+						Name:      "view-pods",
+						RoleRef:   K8sRoleBindingRoleRef{Kind: "Role", Name: "pod-viewer"},
+						Subjects:  []K8sRoleBindingSubject{{Kind: "User", Name: "alice"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid delete with resourceVersion",
+			payload: K8sRoleBindingBatchRequest{
+				Operations: []K8sRoleBindingBatchOperation{
+					{Op: "delete", Namespace: "team-a", Name: "view-pods", ResourceVersion: "123"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
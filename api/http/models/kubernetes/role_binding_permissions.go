@@ -0,0 +1,15 @@
+package kubernetes
+
+// K8sRoleBindingPermissions describes what actions the requesting user is authorized to perform against a
+// given role binding, as determined by a SelfSubjectAccessReview against its namespace.
+type K8sRoleBindingPermissions struct {
+	CanUpdate bool `json:"canUpdate"`
+	CanDelete bool `json:"canDelete"`
+}
+
+// K8sRoleBindingWithPermissions annotates a role binding with the caller's effective permissions over it.
+// It is only populated when the listing is requested with checkAccess=true.
+type K8sRoleBindingWithPermissions struct {
+	K8sRoleBinding
+	Permissions K8sRoleBindingPermissions `json:"permissions"`
+}
@@ -0,0 +1,29 @@
+package portainer
+
+import (
+	models "github.com/portainer/portainer/api/http/models/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// KubeClient represents a client to interact with a Kubernetes environment, backed by the concrete
+// implementation in api/kubernetes/cli.
+type KubeClient interface {
+	GetRoleBindings(namespace string) ([]models.K8sRoleBinding, error)
+	CreateRoleBinding(namespace string, payload models.K8sRoleBindingCreateRequest) (models.K8sRoleBinding, error)
+	UpdateRoleBinding(namespace, name string, payload models.K8sRoleBindingUpdateRequest) (models.K8sRoleBinding, error)
+	DeleteRoleBindings(payload models.K8sRoleBindingDeleteRequests) error
+	DeleteRoleBindingWithPrecondition(namespace, name, resourceVersion string) error
+	UpdateRoleBindingWithPrecondition(namespace, name, resourceVersion string, payload models.K8sRoleBindingUpdateRequest) (models.K8sRoleBinding, error)
+	GetRoleBindingPermissions(namespace string) (models.K8sRoleBindingPermissions, error)
+
+	GetClusterRoleBindings() ([]models.K8sRoleBinding, error)
+	CreateClusterRoleBinding(payload models.K8sClusterRoleBindingCreateRequest) (models.K8sRoleBinding, error)
+	UpdateClusterRoleBinding(name string, payload models.K8sClusterRoleBindingUpdateRequest) (models.K8sRoleBinding, error)
+	DeleteClusterRoleBindings(payload models.K8sClusterRoleBindingDeleteRequests) error
+
+	GetRole(namespace, name string) (*rbacv1.Role, error)
+	GetClusterRole(name string) (*rbacv1.ClusterRole, error)
+	GetServiceAccount(namespace, name string) (*corev1.ServiceAccount, error)
+}
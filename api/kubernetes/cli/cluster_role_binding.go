@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+
+	models "github.com/portainer/portainer/api/http/models/kubernetes"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetClusterRoleBindings returns a list of all cluster role bindings within the cluster.
+func (kcl *KubeClient) GetClusterRoleBindings() ([]models.K8sRoleBinding, error) {
+	clusterRoleBindings, err := kcl.cli.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.K8sRoleBinding, 0, len(clusterRoleBindings.Items))
+	for _, crb := range clusterRoleBindings.Items {
+		results = append(results, parseClusterRoleBinding(crb))
+	}
+
+	return results, nil
+}
+
+// CreateClusterRoleBinding creates a cluster role binding from the given payload.
+func (kcl *KubeClient) CreateClusterRoleBinding(payload models.K8sClusterRoleBindingCreateRequest) (models.K8sRoleBinding, error) {
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: payload.Name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     payload.RoleRef,
+		},
+		Subjects: toRbacSubjects(payload.Subjects),
+	}
+
+	created, err := kcl.cli.RbacV1().ClusterRoleBindings().Create(context.TODO(), clusterRoleBinding, metav1.CreateOptions{})
+	if err != nil {
+		return models.K8sRoleBinding{}, err
+	}
+
+	return parseClusterRoleBinding(*created), nil
+}
+
+// UpdateClusterRoleBinding updates the roleRef and subjects of an existing cluster role binding.
+func (kcl *KubeClient) UpdateClusterRoleBinding(name string, payload models.K8sClusterRoleBindingUpdateRequest) (models.K8sRoleBinding, error) {
+	existing, err := kcl.cli.RbacV1().ClusterRoleBindings().Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return models.K8sRoleBinding{}, err
+	}
+
+	existing.RoleRef = rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     payload.RoleRef,
+	}
+	existing.Subjects = toRbacSubjects(payload.Subjects)
+
+	updated, err := kcl.cli.RbacV1().ClusterRoleBindings().Update(context.TODO(), existing, metav1.UpdateOptions{})
+	if err != nil {
+		return models.K8sRoleBinding{}, err
+	}
+
+	return parseClusterRoleBinding(*updated), nil
+}
+
+// DeleteClusterRoleBindings deletes the given cluster role bindings. When a UID is supplied for an entry,
+// it is sent as a deletion precondition so a binding recreated under the same name since it was listed
+// (the ABA problem) is not deleted out from under its new owner.
+func (kcl *KubeClient) DeleteClusterRoleBindings(payload models.K8sClusterRoleBindingDeleteRequests) error {
+	propagation := metav1.DeletePropagationBackground
+	gracePeriod := int64(0)
+
+	for _, binding := range payload {
+		deleteOptions := metav1.DeleteOptions{
+			PropagationPolicy:  &propagation,
+			GracePeriodSeconds: &gracePeriod,
+		}
+
+		if binding.UID != "" {
+			uid := types.UID(binding.UID)
+			deleteOptions.Preconditions = &metav1.Preconditions{UID: &uid}
+		}
+
+		if err := kcl.cli.RbacV1().ClusterRoleBindings().Delete(context.TODO(), binding.Name, deleteOptions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toRbacSubjects(subjects []models.K8sRoleBindingSubject) []rbacv1.Subject {
+	result := make([]rbacv1.Subject, 0, len(subjects))
+	for _, subject := range subjects {
+		result = append(result, rbacv1.Subject{
+			Kind:      subject.Kind,
+			Name:      subject.Name,
+			Namespace: subject.Namespace,
+		})
+	}
+
+	return result
+}
+
+func parseClusterRoleBinding(crb rbacv1.ClusterRoleBinding) models.K8sRoleBinding {
+	subjects := make([]models.K8sRoleBindingSubject, 0, len(crb.Subjects))
+	for _, subject := range crb.Subjects {
+		subjects = append(subjects, models.K8sRoleBindingSubject{
+			Kind:      subject.Kind,
+			Name:      subject.Name,
+			Namespace: subject.Namespace,
+		})
+	}
+
+	return models.K8sRoleBinding{
+		Name: crb.Name,
+		UID:  string(crb.UID),
+		RoleRef: models.K8sRoleBindingRoleRef{
+			Kind: crb.RoleRef.Kind,
+			Name: crb.RoleRef.Name,
+		},
+		Subjects: subjects,
+	}
+}
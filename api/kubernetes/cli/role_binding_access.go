@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+
+	models "github.com/portainer/portainer/api/http/models/kubernetes"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetRoleBindingPermissions runs a SelfSubjectAccessReview for the update and delete verbs against
+// rolebindings in the given namespace, so callers can tell whether the current user is actually
+// authorized to act on the role bindings they are listing. The get verb is deliberately not checked here:
+// callers only reach this once GetRoleBindings("") has already succeeded, so get access is already proven
+// and a separate SAR for it would be redundant.
+func (kcl *KubeClient) GetRoleBindingPermissions(namespace string) (models.K8sRoleBindingPermissions, error) {
+	canUpdate, err := kcl.canPerformRoleBindingAction(namespace, "update")
+	if err != nil {
+		return models.K8sRoleBindingPermissions{}, err
+	}
+
+	canDelete, err := kcl.canPerformRoleBindingAction(namespace, "delete")
+	if err != nil {
+		return models.K8sRoleBindingPermissions{}, err
+	}
+
+	return models.K8sRoleBindingPermissions{CanUpdate: canUpdate, CanDelete: canDelete}, nil
+}
+
+func (kcl *KubeClient) canPerformRoleBindingAction(namespace, verb string) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     "rbac.authorization.k8s.io",
+				Resource:  "rolebindings",
+			},
+		},
+	}
+
+	result, err := kcl.cli.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	return result.Status.Allowed, nil
+}